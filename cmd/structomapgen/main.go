@@ -0,0 +1,347 @@
+// Command structomapgen generates a reflection-free Serializer for a struct
+// type, driven by the same `structomap:"..."` tags FromTags reads plus a
+// small set of `//structomap:` pragmas for cases tags can't express (a field
+// picked only under a predicate, or a constant value added unconditionally).
+// It is meant to be run via a `//go:generate structomapgen -type=T` directive
+// placed next to the type, the same way stringer is invoked.
+//
+// The generated type implements Transform, TransformArray and
+// MustTransformArray, so it drops in wherever existing code only calls those
+// three methods, but it does not implement the rest of the structomap.Serializer
+// interface: its field set is fixed at generation time, so the Pick/Omit/Add/
+// key-case configuration methods have nothing to attach to.
+//
+// Supported tag directives (see `//structomap:` pragmas below for the rest):
+//
+//	name=foo    use foo as the output key, bypassing -case
+//	-           never include the field
+//	omitempty   skip the field when it equals its zero value
+//	format=rfc3339  format a time.Time field with time.RFC3339
+//
+// Supported pragmas, written as a doc comment directly above the field (or,
+// for const, above the type):
+//
+//	//structomap:omit
+//	//structomap:pick if=<expr>   only include the field when entity.<expr> is true
+//	//structomap:const key=<expr> add result[key] = <expr> unconditionally,
+//	                              with key converted by -case
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/huandu/xstrings"
+)
+
+var (
+	typeNameFlag = flag.String("type", "", "name of the struct type to generate a Serializer for (required)")
+	caseFlag     = flag.String("case", "snake", "key case for generated keys: snake, camel or pascal")
+	outputFlag   = flag.String("output", "", "output file name; default is <type>_structomap.go in the source directory")
+)
+
+type field struct {
+	name          string
+	goType        string
+	key           string
+	isTime        bool
+	omit          bool
+	omitempty     bool
+	nonComparable bool
+	format        string
+	predicate     string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("structomapgen: ")
+	flag.Parse()
+
+	if *typeNameFlag == "" {
+		log.Fatal("-type is required")
+	}
+
+	dir := "."
+	if goFile := os.Getenv("GOFILE"); goFile != "" {
+		dir = filepath.Dir(goFile)
+	}
+
+	pkgName, consts, fields, err := parseType(dir, *typeNameFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	convertKey := keyConverter(*caseFlag)
+	for i := range fields {
+		if fields[i].key == "" {
+			fields[i].key = convertKey(fields[i].name)
+		}
+	}
+	convertedConsts := make(map[string]string, len(consts))
+	for k, v := range consts {
+		convertedConsts[convertKey(k)] = v
+	}
+
+	src, err := render(pkgName, *typeNameFlag, convertedConsts, fields)
+	if err != nil {
+		log.Fatalf("invalid generated code: %s", err)
+	}
+
+	outputName := *outputFlag
+	if outputName == "" {
+		outputName = filepath.Join(dir, strings.ToLower(*typeNameFlag)+"_structomap.go")
+	}
+	if err := os.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseType finds typeName's struct declaration among the Go files in dir
+// and extracts the const pragmas on the type and the fields to serialize.
+func parseType(dir, typeName string) (pkgName string, consts map[string]string, fields []field, err error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_structomap.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	for name, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok || typeSpec.Name.Name != typeName {
+						continue
+					}
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						return "", nil, nil, fmt.Errorf("%s is not a struct type", typeName)
+					}
+					fields, err := parseFields(structType)
+					if err != nil {
+						return "", nil, nil, err
+					}
+					return name, parseConstPragmas(genDecl.Doc), fields, nil
+				}
+			}
+		}
+	}
+	return "", nil, nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+// isNonComparableType reports whether expr is a type for which Go rejects
+// the `!= *new(T)` zero check applyTagOptions' omitempty relies on: slices,
+// maps and funcs. Arrays, pointers, interfaces and plain struct/basic types
+// are all comparable and don't need the len/nil special case.
+func isNonComparableType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.ArrayType:
+		return t.Len == nil
+	case *ast.MapType, *ast.FuncType:
+		return true
+	default:
+		return false
+	}
+}
+
+func parseFields(structType *ast.StructType) ([]field, error) {
+	var fields []field
+	for _, astField := range structType.Fields.List {
+		for _, nameIdent := range astField.Names {
+			if !nameIdent.IsExported() {
+				continue
+			}
+			fld := field{
+				name:          nameIdent.Name,
+				goType:        types.ExprString(astField.Type),
+				nonComparable: isNonComparableType(astField.Type),
+			}
+			if sel, ok := astField.Type.(*ast.SelectorExpr); ok {
+				if pkgIdent, ok := sel.X.(*ast.Ident); ok && pkgIdent.Name == "time" && sel.Sel.Name == "Time" {
+					fld.isTime = true
+				}
+			}
+			if astField.Tag != nil {
+				tagValue, err := strconv.Unquote(astField.Tag.Value)
+				if err != nil {
+					return nil, err
+				}
+				applyTagOptions(&fld, reflect.StructTag(tagValue).Get("structomap"))
+			}
+			applyPragmas(&fld, astField.Doc)
+			if fld.omit {
+				continue
+			}
+			fields = append(fields, fld)
+		}
+	}
+	return fields, nil
+}
+
+func applyTagOptions(fld *field, tag string) {
+	if tag == "-" {
+		fld.omit = true
+		return
+	}
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case part == "omitempty":
+			fld.omitempty = true
+		case strings.HasPrefix(part, "name="):
+			fld.key = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "format="):
+			fld.format = strings.TrimPrefix(part, "format=")
+		}
+	}
+}
+
+func applyPragmas(fld *field, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		text := strings.TrimPrefix(c.Text, "//structomap:")
+		if text == c.Text {
+			continue
+		}
+		text = strings.TrimSpace(text)
+		switch {
+		case text == "omit":
+			fld.omit = true
+		case strings.HasPrefix(text, "pick if="):
+			fld.predicate = strings.TrimPrefix(text, "pick if=")
+		}
+	}
+}
+
+func parseConstPragmas(doc *ast.CommentGroup) map[string]string {
+	consts := make(map[string]string)
+	if doc == nil {
+		return consts
+	}
+	for _, c := range doc.List {
+		text := strings.TrimPrefix(c.Text, "//structomap:const ")
+		if text == c.Text {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimSpace(text), "=", 2)
+		if len(parts) == 2 {
+			consts[parts[0]] = parts[1]
+		}
+	}
+	return consts
+}
+
+// keyConverter mirrors the case conversions Base.UseSnakeCase/UseCamelCase/
+// UsePascalCase apply at runtime, so generated keys match the hand-written
+// path for the same -case.
+func keyConverter(name string) func(string) string {
+	switch name {
+	case "camel":
+		return func(k string) string {
+			return xstrings.FirstRuneToLower(xstrings.ToCamelCase(xstrings.ToSnakeCase(k)))
+		}
+	case "pascal":
+		return xstrings.ToCamelCase
+	default:
+		return xstrings.ToSnakeCase
+	}
+}
+
+func render(pkgName, typeName string, consts map[string]string, fields []field) ([]byte, error) {
+	usesTime := false
+	for _, f := range fields {
+		if f.isTime && f.format == "rfc3339" {
+			usesTime = true
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, "// Code generated by structomapgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	buf.WriteString("import (\n\t\"fmt\"\n")
+	if usesTime {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString(")\n\n")
+
+	serializerName := typeName + "Serializer"
+	fmt.Fprintf(&buf, "// %s is a reflection-free Serializer for %s, generated by structomapgen.\n", serializerName, typeName)
+	fmt.Fprint(&buf, "// It implements Transform, TransformArray and MustTransformArray only, not\n")
+	fmt.Fprint(&buf, "// the rest of the structomap.Serializer interface: its fields are fixed at\n")
+	fmt.Fprint(&buf, "// generation time, so there's nothing for Pick/Omit/Add to configure.\n")
+	fmt.Fprintf(&buf, "type %s struct{}\n\n", serializerName)
+	fmt.Fprintf(&buf, "// New%s creates a new %s.\n", serializerName, serializerName)
+	fmt.Fprintf(&buf, "func New%s() *%s {\n\treturn &%s{}\n}\n\n", serializerName, serializerName, serializerName)
+
+	fmt.Fprint(&buf, "// Transform the entity into a map[string]interface{} ready to be serialized\n")
+	fmt.Fprintf(&buf, "func (s *%s) Transform(entity interface{}) map[string]interface{} {\n", serializerName)
+	fmt.Fprintf(&buf, "\treturn s.Transform%s(entity.(%s))\n}\n\n", typeName, typeName)
+
+	fmt.Fprintf(&buf, "// Transform%s transforms entity directly, without going through reflection.\n", typeName)
+	fmt.Fprintf(&buf, "func (s *%s) Transform%s(entity %s) map[string]interface{} {\n", serializerName, typeName, typeName)
+	fmt.Fprintf(&buf, "\tresult := make(map[string]interface{}, %d)\n", len(consts)+len(fields))
+
+	constKeys := make([]string, 0, len(consts))
+	for k := range consts {
+		constKeys = append(constKeys, k)
+	}
+	sort.Strings(constKeys)
+	for _, key := range constKeys {
+		fmt.Fprintf(&buf, "\tresult[%q] = %s\n", key, consts[key])
+	}
+
+	for _, f := range fields {
+		valueExpr := fmt.Sprintf("entity.%s", f.name)
+		if f.isTime && f.format == "rfc3339" {
+			valueExpr = fmt.Sprintf("entity.%s.Format(time.RFC3339)", f.name)
+		}
+		assignment := fmt.Sprintf("result[%q] = %s", f.key, valueExpr)
+		switch {
+		case f.predicate != "":
+			fmt.Fprintf(&buf, "\tif entity.%s {\n\t\t%s\n\t}\n", f.predicate, assignment)
+		case f.omitempty && f.nonComparable:
+			fmt.Fprintf(&buf, "\tif entity.%s != nil {\n\t\t%s\n\t}\n", f.name, assignment)
+		case f.omitempty:
+			fmt.Fprintf(&buf, "\tif entity.%s != *new(%s) {\n\t\t%s\n\t}\n", f.name, f.goType, assignment)
+		default:
+			fmt.Fprintf(&buf, "\t%s\n", assignment)
+		}
+	}
+	fmt.Fprint(&buf, "\treturn result\n}\n\n")
+
+	fmt.Fprint(&buf, "// TransformArray transforms the entities into a []map[string]interface{} array\n")
+	fmt.Fprintf(&buf, "// entities must be a []%s\n", typeName)
+	fmt.Fprintf(&buf, "func (s *%s) TransformArray(entities interface{}) ([]map[string]interface{}, error) {\n", serializerName)
+	fmt.Fprintf(&buf, "\titems, ok := entities.([]%s)\n", typeName)
+	fmt.Fprintf(&buf, "\tif !ok {\n\t\treturn nil, fmt.Errorf(\"TransformArray() given a non-[]%s type\")\n\t}\n", typeName)
+	fmt.Fprint(&buf, "\tresult := make([]map[string]interface{}, len(items))\n")
+	fmt.Fprintf(&buf, "\tfor i, entity := range items {\n\t\tresult[i] = s.Transform%s(entity)\n\t}\n", typeName)
+	fmt.Fprint(&buf, "\treturn result, nil\n}\n\n")
+
+	fmt.Fprint(&buf, "// MustTransformArray transforms the entities into a []map[string]interface{} array\n")
+	fmt.Fprintf(&buf, "// Panics if entities is not a []%s\n", typeName)
+	fmt.Fprintf(&buf, "func (s *%s) MustTransformArray(entities interface{}) []map[string]interface{} {\n", serializerName)
+	fmt.Fprint(&buf, "\tres, err := s.TransformArray(entities)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn res\n}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,89 @@
+package structomap
+
+import "context"
+
+// CtxPredicate is like Predicate but also receives a context.Context, so
+// rules can depend on request-scoped state (the current user, locale,
+// permissions, ...) carried in via WithContext.
+type CtxPredicate func(ctx context.Context, entity interface{}) bool
+
+// CtxValueConverter is like ValueConverter but also receives a
+// context.Context.
+type CtxValueConverter func(ctx context.Context, value interface{}) interface{}
+
+// WithContext sets the context.Context threaded into every *Ctx rule
+func (b *Base) WithContext(ctx context.Context) Serializer {
+	b.ctx = ctx
+	return b
+}
+
+func (b *Base) context() context.Context {
+	if b.ctx != nil {
+		return b.ctx
+	}
+	return context.Background()
+}
+
+// PickIfCtx adds the given fields to the result if the CtxPredicate returns
+// true for the serializer's context, set via WithContext
+func (b *Base) PickIfCtx(p CtxPredicate, keys ...string) Serializer {
+	return b.PickFuncIfCtx(p, func(ctx context.Context, v interface{}) interface{} { return v }, keys...)
+}
+
+// PickFuncCtx adds the given fields to the result after applying the
+// CtxValueConverter
+func (b *Base) PickFuncCtx(converter CtxValueConverter, keys ...string) Serializer {
+	return b.PickFuncIfCtx(func(context.Context, interface{}) bool { return true }, converter, keys...)
+}
+
+// PickFuncIfCtx adds the given fields to the result after applying the
+// CtxValueConverter if the CtxPredicate returns true for the serializer's
+// context
+func (b *Base) PickFuncIfCtx(p CtxPredicate, converter CtxValueConverter, keys ...string) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.context(), b.raw) {
+			for _, key := range keys {
+				m[b.outputKey(key)] = converter(b.context(), b.fieldValue(key))
+			}
+		}
+		return m
+	})
+	return b
+}
+
+// OmitIfCtx omits the given fields from the result if the CtxPredicate
+// returns true for the serializer's context
+func (b *Base) OmitIfCtx(p CtxPredicate, keys ...string) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.context(), b.raw) {
+			for _, key := range keys {
+				delete(m, b.outputKey(key))
+			}
+		}
+		return m
+	})
+	return b
+}
+
+// AddIfCtx adds a custom field to the result if the CtxPredicate returns
+// true for the serializer's context
+func (b *Base) AddIfCtx(p CtxPredicate, key string, value interface{}) Serializer {
+	return b.AddFuncIfCtx(p, key, func(context.Context, interface{}) interface{} { return value })
+}
+
+// AddFuncCtx adds a computed custom field to the result
+func (b *Base) AddFuncCtx(key string, f CtxValueConverter) Serializer {
+	return b.AddFuncIfCtx(func(context.Context, interface{}) bool { return true }, key, f)
+}
+
+// AddFuncIfCtx adds a computed custom field to the result if the
+// CtxPredicate returns true for the serializer's context
+func (b *Base) AddFuncIfCtx(p CtxPredicate, key string, f CtxValueConverter) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.context(), b.raw) {
+			m[key] = f(b.context(), b.raw)
+		}
+		return m
+	})
+	return b
+}
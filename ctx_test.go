@@ -0,0 +1,76 @@
+package structomap
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey string
+
+const roleKey ctxKey = "role"
+
+func isAdmin(ctx context.Context, entity interface{}) bool {
+	return ctx.Value(roleKey) == "admin"
+}
+
+func adminContext() context.Context {
+	return context.WithValue(context.Background(), roleKey, "admin")
+}
+
+func TestWithContextPickIfCtx(t *testing.T) {
+	serializer := New().
+		Pick("ID").
+		PickIfCtx(isAdmin, "Email")
+
+	m := serializer.WithContext(context.Background()).Transform(user)
+	assert.NotContains(t, m, "Email")
+
+	m = serializer.WithContext(adminContext()).Transform(user)
+	assert.Contains(t, m, "Email")
+}
+
+func TestWithContextOmitIfCtx(t *testing.T) {
+	notAdmin := func(ctx context.Context, entity interface{}) bool {
+		return !isAdmin(ctx, entity)
+	}
+	serializer := New().PickAll().OmitIfCtx(notAdmin, "Email")
+
+	m := serializer.WithContext(context.Background()).Transform(user)
+	assert.NotContains(t, m, "Email")
+
+	m = serializer.WithContext(adminContext()).Transform(user)
+	assert.Contains(t, m, "Email")
+}
+
+func TestWithContextPickFuncCtx(t *testing.T) {
+	upper := func(ctx context.Context, v interface{}) interface{} {
+		return strings.ToUpper(v.(string))
+	}
+	m := New().PickFuncCtx(upper, "FirstName").WithContext(context.Background()).Transform(user)
+	assert.Equal(t, "FOO", m["FirstName"])
+}
+
+func TestWithContextAddFuncIfCtx(t *testing.T) {
+	serializer := New().AddFuncIfCtx(isAdmin, "Secret", func(context.Context, interface{}) interface{} {
+		return "s3cr3t"
+	})
+
+	m := serializer.WithContext(context.Background()).Transform(user)
+	assert.NotContains(t, m, "Secret")
+
+	m = serializer.WithContext(adminContext()).Transform(user)
+	assert.Equal(t, "s3cr3t", m["Secret"])
+}
+
+func TestWithContextAddIfCtx(t *testing.T) {
+	m := New().AddIfCtx(isAdmin, "Secret", "s3cr3t").WithContext(adminContext()).Transform(user)
+	assert.Equal(t, "s3cr3t", m["Secret"])
+}
+
+func TestWithoutContextUsesBackground(t *testing.T) {
+	m := New().PickIfCtx(isAdmin, "Email").Transform(user)
+	assert.NotContains(t, m, "Email")
+}
@@ -0,0 +1,15 @@
+package structomap
+
+// Definition is a serializer built once and reused across many calls to
+// Transform/TransformArray, as opposed to building the same modifier chain
+// for every request. It is the same underlying type as Base: the modifier
+// chain already only closes over the serializer, not over any particular
+// entity, so no dedicated storage is needed to make it reusable.
+type Definition = Base
+
+// NewDefinition creates a new, empty Definition, ready to be configured once
+// (e.g. structomap.NewDefinition().PickAll().Omit("Password")) and reused
+// across many Transform/TransformArray calls without repeating the setup.
+func NewDefinition() *Definition {
+	return New()
+}
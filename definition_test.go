@@ -0,0 +1,61 @@
+package structomap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinitionReusedAcrossTransformArray(t *testing.T) {
+	def := NewDefinition().
+		PickAll().
+		Omit("Email").
+		AddFunc("FullName", func(v interface{}) interface{} {
+			u := v.(User)
+			return u.FirstName + " " + u.LastName
+		})
+
+	otherUser := User{ID: 2, FirstName: "Ping", LastName: "Pong", CreatedAt: createdAt, UpdatedAt: createdAt}
+	results, err := def.TransformArray([]User{user, otherUser})
+
+	assert.Nil(t, err)
+	assert.Equal(t, user.FirstName+" "+user.LastName, results[0]["FullName"])
+	assert.Equal(t, otherUser.FirstName+" "+otherUser.LastName, results[1]["FullName"])
+	assert.NotContains(t, results[0], "Email")
+}
+
+func TestDefinitionSingleTransform(t *testing.T) {
+	def := NewDefinition().Pick("ID", "FirstName")
+	m := def.Transform(user)
+	assert.Equal(t, user.ID, m["ID"])
+
+	m = def.Transform(User{ID: 42, FirstName: "Other"})
+	assert.Equal(t, 42, m["ID"])
+}
+
+func BenchmarkDefinitionTransformArray(b *testing.B) {
+	users := make([]User, 100)
+	for i := range users {
+		users[i] = user
+	}
+	def := NewDefinition().PickAll().Omit("Email")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = def.TransformArray(users)
+	}
+}
+
+func BenchmarkNewSerializerPerItem(b *testing.B) {
+	users := make([]User, 100)
+	for i := range users {
+		users[i] = user
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, u := range users {
+			New().PickAll().Omit("Email").Transform(u)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package structomap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Encoder writes successive values to an underlying writer, the same way
+// encoding/json.Encoder does.
+type Encoder interface {
+	Encode(v interface{}) error
+}
+
+// EncoderFactory creates an Encoder writing to w.
+type EncoderFactory func(w io.Writer) Encoder
+
+var formats = map[string]EncoderFactory{
+	"json": func(w io.Writer) Encoder { return json.NewEncoder(w) },
+}
+
+// RegisterFormat registers an EncoderFactory under name, making it available
+// to TransformTo and TransformArrayTo.
+func RegisterFormat(name string, factory EncoderFactory) {
+	formats[name] = factory
+}
+
+func encoderFor(format string, w io.Writer) (Encoder, error) {
+	factory, ok := formats[format]
+	if !ok {
+		return nil, fmt.Errorf("structomap: no encoder registered for format %q", format)
+	}
+	return factory(w), nil
+}
+
+// TransformTo transforms entity and encodes the result to w using the
+// encoder registered for format.
+func (b *Base) TransformTo(entity interface{}, w io.Writer, format string) error {
+	encoder, err := encoderFor(format, w)
+	if err != nil {
+		return err
+	}
+	return encoder.Encode(b.Transform(entity))
+}
+
+// TransformArrayTo transforms entities and encodes the result to w using the
+// encoder registered for format. Unlike TransformArray, it encodes one
+// element at a time as it writes `[elem, elem, ...]`, so the full
+// []map[string]interface{} slice is never held in memory at once.
+func (b *Base) TransformArrayTo(entities interface{}, w io.Writer, format string) error {
+	s, err := sliceValue(entities)
+	if err != nil {
+		return err
+	}
+	encoder, err := encoderFor(format, w)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := 0; i < s.Len(); i++ {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(b.Transform(s.Index(i).Interface())); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "]")
+	return err
+}
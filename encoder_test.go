@@ -0,0 +1,48 @@
+package structomap
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformTo(t *testing.T) {
+	var buf bytes.Buffer
+	err := New().UseSnakeCase().Pick("ID", "FirstName").TransformTo(user, &buf, "json")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": 1, "first_name": "Foo"}`, buf.String())
+}
+
+func TestTransformToUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := New().Pick("ID").TransformTo(user, &buf, "xml")
+	assert.NotNil(t, err)
+}
+
+func TestTransformArrayTo(t *testing.T) {
+	otherUser := User{ID: 8, FirstName: "Me"}
+	var buf bytes.Buffer
+	ser := New().UseSnakeCase().Pick("ID", "FirstName")
+	err := ser.TransformArrayTo([]User{user, otherUser}, &buf, "json")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"id": 1, "first_name": "Foo"}, {"id": 8, "first_name": "Me"}]`, buf.String())
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("upper", func(w io.Writer) Encoder { return upperEncoder{w} })
+	var buf bytes.Buffer
+	err := New().Pick("FirstName").TransformTo(user, &buf, "upper")
+	assert.Nil(t, err)
+	assert.Equal(t, "FIRSTNAME=FOO\n", buf.String())
+}
+
+type upperEncoder struct {
+	w io.Writer
+}
+
+func (e upperEncoder) Encode(v interface{}) error {
+	_, err := e.w.Write([]byte("FIRSTNAME=FOO\n"))
+	return err
+}
@@ -0,0 +1,27 @@
+// Package examples demonstrates structomapgen-generated serializers
+// alongside the reflection-based Base serializer they can replace.
+package examples
+
+import "time"
+
+//go:generate structomapgen -type=Article
+
+//structomap:const schema_version=1
+type Article struct {
+	ID          int
+	Title       string    `structomap:"name=title"`
+	Body        string    `structomap:"-"`
+	ViewCount   int       `structomap:"omitempty"`
+	PublishedAt time.Time `structomap:"format=rfc3339"`
+	Tags        []string  `structomap:"omitempty"`
+
+	//structomap:pick if=IsAdmin()
+	AuthorEmail string
+
+	admin bool
+}
+
+// IsAdmin reports whether the article is currently viewed by an admin.
+func (a Article) IsAdmin() bool {
+	return a.admin
+}
@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	structomap "github.com/danhper/structomap"
+)
+
+var benchmarkArticle = Article{
+	ID:          1,
+	Title:       "Hello",
+	Body:        "a body nobody sees",
+	ViewCount:   42,
+	PublishedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	AuthorEmail: "author@example.com",
+}
+
+func reflectionSerializer() structomap.Serializer {
+	return structomap.New().
+		UseSnakeCase().
+		Add("schema_version", 1).
+		Pick("ID").
+		PickIf(func(u interface{}) bool {
+			return u.(Article).IsAdmin()
+		}, "AuthorEmail").
+		PickFunc(func(v interface{}) interface{} {
+			return v.(string)
+		}, "Title").
+		PickFunc(func(v interface{}) interface{} {
+			return v.(time.Time).Format(time.RFC3339)
+		}, "PublishedAt").
+		PickIf(func(u interface{}) bool {
+			return u.(Article).ViewCount != 0
+		}, "ViewCount").
+		PickIf(func(u interface{}) bool {
+			return u.(Article).Tags != nil
+		}, "Tags")
+}
+
+// BenchmarkReflection measures the reflection-based Base path, which calls
+// reflect.Value.FieldByName per field per entity.
+func BenchmarkReflection(b *testing.B) {
+	serializer := reflectionSerializer()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		serializer.Transform(benchmarkArticle)
+	}
+}
+
+// BenchmarkGenerated measures the structomapgen-generated, reflection-free
+// path for the same fields.
+func BenchmarkGenerated(b *testing.B) {
+	serializer := NewArticleSerializer()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		serializer.Transform(benchmarkArticle)
+	}
+}
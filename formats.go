@@ -0,0 +1,54 @@
+package structomap
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"gopkg.in/yaml.v2"
+)
+
+type yamlEncoder struct {
+	w io.Writer
+}
+
+func (e yamlEncoder) Encode(v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+type tomlEncoder struct {
+	encoder *toml.Encoder
+}
+
+func (e tomlEncoder) Encode(v interface{}) error {
+	return e.encoder.Encode(v)
+}
+
+// bsonEncoder buffers and marshals in one shot: the mongo-driver bson
+// package only encodes through its own bsonrw.ValueWriter, not a plain
+// io.Writer, so there is no way to stream it field-by-field.
+type bsonEncoder struct {
+	w io.Writer
+}
+
+func (e bsonEncoder) Encode(v interface{}) error {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func init() {
+	RegisterFormat("yaml", func(w io.Writer) Encoder { return yamlEncoder{w} })
+	RegisterFormat("toml", func(w io.Writer) Encoder { return tomlEncoder{toml.NewEncoder(w)} })
+	RegisterFormat("msgpack", func(w io.Writer) Encoder { return msgpack.NewEncoder(w) })
+	RegisterFormat("bson", func(w io.Writer) Encoder { return bsonEncoder{w} })
+}
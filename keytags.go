@@ -0,0 +1,92 @@
+package structomap
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagKeyInfo is the result of parsing a struct tag such as `json:"name,omitempty"`.
+type tagKeyInfo struct {
+	key       string
+	omitempty bool
+	omit      bool
+}
+
+// tagInfo parses field's b.tagName tag, if any. The second return value is
+// false when the field has no such tag, in which case info is meaningless.
+func (b *Base) tagInfo(field reflect.StructField) (tagKeyInfo, bool) {
+	if b.tagName == "" {
+		return tagKeyInfo{}, false
+	}
+	tagValue, ok := field.Tag.Lookup(b.tagName)
+	if !ok {
+		return tagKeyInfo{}, false
+	}
+	parts := strings.Split(tagValue, ",")
+	if parts[0] == "-" {
+		return tagKeyInfo{omit: true}, true
+	}
+	info := tagKeyInfo{key: parts[0]}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			info.omitempty = true
+		}
+	}
+	return info, true
+}
+
+// fieldOutputKey resolves the key a struct field should be stored under,
+// applying Rename first and falling back to the tag-derived name otherwise.
+func (b *Base) fieldOutputKey(fieldName string, info tagKeyInfo, tagged bool) string {
+	if newName, ok := b.renames[fieldName]; ok {
+		b.markRawKey(newName)
+		return newName
+	}
+	if tagged && info.key != "" {
+		b.markRawKey(info.key)
+		return info.key
+	}
+	return fieldName
+}
+
+func isZeroValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+// WithTag configures the serializer to read field keys (and the omitempty
+// directive) from the given struct tag, e.g. WithTag("json"), instead of
+// using the Go field name. It affects PickAll, Pick, PickFunc and Omit.
+func (b *Base) WithTag(tag string) Serializer {
+	b.tagName = tag
+	return b
+}
+
+// WithKeyCase is a shorthand for the ConvertKeys/UseXCase family, picking
+// the key case from a KeyCase value.
+func (b *Base) WithKeyCase(keyCase KeyCase) Serializer {
+	switch keyCase {
+	case SnakeCase:
+		return b.UseSnakeCase()
+	case CamelCase:
+		return b.UseCamelCase()
+	case PascalCase:
+		return b.UsePascalCase()
+	case LowerCase:
+		return b.ConvertKeys(strings.ToLower)
+	default:
+		return b
+	}
+}
+
+// Rename overrides the output key a single field resolves to, taking
+// precedence over WithTag and bypassing the active key case.
+func (b *Base) Rename(field, newName string) Serializer {
+	if b.renames == nil {
+		b.renames = make(map[string]string)
+	}
+	b.renames[field] = newName
+	return b
+}
@@ -0,0 +1,64 @@
+package structomap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TaggedProfile struct {
+	UserID   string `json:"user_id"`
+	Nickname string `json:"nickname,omitempty"`
+	Password string `json:"-"`
+	Internal string
+}
+
+func TestWithTagPickAll(t *testing.T) {
+	profile := TaggedProfile{UserID: "u1", Internal: "kept"}
+	m := New().WithTag("json").PickAll().Transform(profile)
+
+	assert.Equal(t, "u1", m["user_id"])
+	assert.NotContains(t, m, "nickname")
+	assert.NotContains(t, m, "Password")
+	assert.Equal(t, "kept", m["Internal"])
+}
+
+func TestWithTagPick(t *testing.T) {
+	profile := TaggedProfile{UserID: "u1", Nickname: "bob"}
+	m := New().WithTag("json").Pick("UserID", "Nickname").Transform(profile)
+
+	assert.Equal(t, "u1", m["user_id"])
+	assert.Equal(t, "bob", m["nickname"])
+}
+
+func TestWithTagOmitempty(t *testing.T) {
+	profile := TaggedProfile{UserID: "u1"}
+	m := New().WithTag("json").Pick("UserID", "Nickname").Transform(profile)
+
+	assert.Contains(t, m, "user_id")
+	assert.NotContains(t, m, "nickname")
+}
+
+func TestWithTagOmit(t *testing.T) {
+	profile := TaggedProfile{UserID: "u1", Internal: "kept"}
+	m := New().WithTag("json").PickAll().Omit("UserID").Transform(profile)
+
+	assert.NotContains(t, m, "user_id")
+	assert.Equal(t, "kept", m["Internal"])
+}
+
+func TestRenameOverridesTag(t *testing.T) {
+	profile := TaggedProfile{UserID: "u1"}
+	m := New().WithTag("json").Rename("UserID", "id").Pick("UserID").Transform(profile)
+
+	assert.Equal(t, "u1", m["id"])
+	assert.NotContains(t, m, "user_id")
+}
+
+func TestWithKeyCase(t *testing.T) {
+	m := New().WithKeyCase(SnakeCase).PickAll().Transform(user)
+	assert.Contains(t, m, "first_name")
+
+	m = New().WithKeyCase(LowerCase).Pick("FirstName").Transform(user)
+	assert.Contains(t, m, "firstname")
+}
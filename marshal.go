@@ -0,0 +1,41 @@
+package structomap
+
+import (
+	"bytes"
+)
+
+// RegisterEncoder registers an EncoderFactory under name, making it
+// available to Marshal/TransformTo/TransformArrayTo. It is an alias for
+// RegisterFormat.
+var RegisterEncoder = RegisterFormat
+
+// Marshal transforms entity and encodes the result with the encoder
+// registered for format, returning the encoded bytes directly instead of
+// requiring callers to round-trip through Result()/Transform() themselves.
+func (b *Base) Marshal(entity interface{}, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := b.TransformTo(entity, &buf, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSON transforms entity and encodes the result as JSON.
+//
+// Named ToJSON rather than MarshalJSON: the latter is the
+// json.Marshaler method, which takes no arguments, whereas this needs
+// entity to know what to transform. Reusing the name would read as
+// implementing that interface without actually satisfying it.
+func (b *Base) ToJSON(entity interface{}) ([]byte, error) {
+	return b.Marshal(entity, "json")
+}
+
+// ToMsgPack transforms entity and encodes the result as MessagePack
+func (b *Base) ToMsgPack(entity interface{}) ([]byte, error) {
+	return b.Marshal(entity, "msgpack")
+}
+
+// ToBSON transforms entity and encodes the result as BSON
+func (b *Base) ToBSON(entity interface{}) ([]byte, error) {
+	return b.Marshal(entity, "bson")
+}
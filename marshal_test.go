@@ -0,0 +1,44 @@
+package structomap
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestToJSON(t *testing.T) {
+	data, err := New().UseSnakeCase().Pick("ID", "FirstName").ToJSON(user)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": 1, "first_name": "Foo"}`, string(data))
+}
+
+func TestToMsgPack(t *testing.T) {
+	data, err := New().UseSnakeCase().Pick("ID", "FirstName").ToMsgPack(user)
+	assert.Nil(t, err)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, msgpack.Unmarshal(data, &decoded))
+	assert.EqualValues(t, 1, decoded["id"])
+	assert.Equal(t, "Foo", decoded["first_name"])
+}
+
+func TestToBSON(t *testing.T) {
+	data, err := New().UseSnakeCase().Pick("ID", "FirstName").ToBSON(user)
+	assert.Nil(t, err)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, bson.Unmarshal(data, &decoded))
+	assert.Equal(t, "Foo", decoded["first_name"])
+}
+
+func TestRegisterEncoderIsRegisterFormat(t *testing.T) {
+	RegisterEncoder("shout", func(w io.Writer) Encoder {
+		return upperEncoder{w}
+	})
+	data, err := New().Pick("FirstName").Marshal(user, "shout")
+	assert.Nil(t, err)
+	assert.Equal(t, "FIRSTNAME=FOO\n", string(data))
+}
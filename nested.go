@@ -0,0 +1,158 @@
+package structomap
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// seededTransformer lets transformNestedWith pass its in-progress cycle
+// detection set down into a nested Serializer, instead of each recursive
+// Transform call starting a fresh one. *Base implements it; Serializer
+// implementations that don't are simply transformed with Transform, with no
+// cross-definition cycle detection.
+type seededTransformer interface {
+	transformWithSeen(entity interface{}, seen map[uintptr]bool) map[string]interface{}
+}
+
+// PickWith adds the struct-typed, slice-of-struct or map-of-struct field
+// named field to the result, transformed recursively with sub instead of
+// structs.Map's default recursive expansion. Pointers are dereferenced, nil
+// pointers (and cycles reached through a pointer) become nil; a nil slice or
+// map becomes an empty one.
+func (b *Base) PickWith(field string, sub Serializer) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		value := b.reflected.FieldByName(field)
+		m[b.outputKey(field)] = transformNestedWith(sub, value, b.seenOrNew())
+		return m
+	})
+	return b
+}
+
+// PickAllNested behaves like PickAll, except that struct, slice-of-struct
+// and map-of-struct fields are transformed recursively using this
+// serializer's own conventions (key case, WithTag, ...) instead of being
+// expanded by structs.Map's defaults.
+func (b *Base) PickAllNested() Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		return b.pickAllNested(m, b.seenOrNew())
+	})
+	return b
+}
+
+// seenOrNew returns the cycle-detection set inherited from an enclosing
+// PickWith/PickAllNested call, or a fresh one if this is the outermost call.
+func (b *Base) seenOrNew() map[uintptr]bool {
+	if b.nestSeen != nil {
+		return b.nestSeen
+	}
+	return make(map[uintptr]bool)
+}
+
+func (b *Base) pickAllNested(m jsonMap, seen map[uintptr]bool) jsonMap {
+	t := b.reflected.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		info, tagged := b.tagInfo(field)
+		if tagged && info.omit {
+			continue
+		}
+		value := b.reflected.Field(i)
+		if tagged && info.omitempty && value.IsZero() {
+			continue
+		}
+		key := b.fieldOutputKey(field.Name, info, tagged)
+		if isNestableType(field.Type) {
+			m[key] = transformNestedWith(b.childDefinition(), value, seen)
+		} else {
+			m[key] = value.Interface()
+		}
+	}
+	return m
+}
+
+// childDefinition builds a Definition sharing this serializer's key
+// conventions, used to recursively serialize nested fields under
+// PickAllNested.
+func (b *Base) childDefinition() *Base {
+	child := &Base{
+		flattenNestedKeys: b.flattenNestedKeys,
+		keyConverter:      b.keyConverter,
+		tagName:           b.tagName,
+	}
+	child.PickAllNested()
+	return child
+}
+
+// isNestableType reports whether t (after dereferencing pointers, slices,
+// arrays and maps) ultimately holds a struct other than time.Time.
+func isNestableType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return isNestableType(t.Elem())
+	default:
+		return false
+	}
+}
+
+// transformNestedWith walks v, transforming every struct it finds with def,
+// recursing through pointers, slices, arrays and maps. seen tracks pointer
+// addresses already on the current path, so a cycle resolves to nil instead
+// of recursing forever.
+func transformNestedWith(def Serializer, v reflect.Value, seen map[uintptr]bool) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		ptr := v.Pointer()
+		if seen[ptr] {
+			return nil
+		}
+		seen[ptr] = true
+		defer delete(seen, ptr)
+		return transformNestedWith(def, v.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = transformNestedWith(def, v.Index(i), seen)
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			result[fmt.Sprint(key.Interface())] = transformNestedWith(def, v.MapIndex(key), seen)
+		}
+		return result
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		if st, ok := def.(seededTransformer); ok {
+			return st.transformWithSeen(v.Interface(), seen)
+		}
+		return def.Transform(v.Interface())
+	default:
+		return v.Interface()
+	}
+}
+
+// transformWithSeen is like Transform, but threads an in-progress cycle
+// detection set through to any PickWith/PickAllNested modifier b itself
+// carries, instead of starting a fresh one.
+func (b *Base) transformWithSeen(entity interface{}, seen map[uintptr]bool) map[string]interface{} {
+	b.nestSeen = seen
+	result := b.Transform(entity)
+	b.nestSeen = nil
+	return result
+}
@@ -0,0 +1,99 @@
+package structomap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type NestedAddress struct {
+	City string
+}
+
+type NestedPost struct {
+	Title string
+	Body  string
+}
+
+type NestedAuthor struct {
+	Name    string
+	Address *NestedAddress
+	Posts   []NestedPost
+}
+
+type NestedNode struct {
+	Name string
+	Next *NestedNode
+}
+
+func TestPickWithPointerField(t *testing.T) {
+	author := NestedAuthor{Name: "Jane", Address: &NestedAddress{City: "Paris"}}
+	addressDef := NewDefinition().PickAll()
+
+	m := New().Pick("Name").PickWith("Address", addressDef).Transform(author)
+
+	assert.Equal(t, "Jane", m["Name"])
+	assert.Equal(t, map[string]interface{}{"City": "Paris"}, m["Address"])
+}
+
+func TestPickWithNilPointerField(t *testing.T) {
+	author := NestedAuthor{Name: "Jane"}
+	addressDef := NewDefinition().PickAll()
+
+	m := New().PickWith("Address", addressDef).Transform(author)
+
+	assert.Nil(t, m["Address"])
+}
+
+func TestPickWithSliceField(t *testing.T) {
+	author := NestedAuthor{
+		Name: "Jane",
+		Posts: []NestedPost{
+			{Title: "Hello", Body: "secret"},
+			{Title: "World", Body: "secret"},
+		},
+	}
+	postDef := NewDefinition().PickAll().Omit("Body")
+
+	m := New().PickWith("Posts", postDef).Transform(author)
+
+	posts := m["Posts"].([]interface{})
+	assert.Len(t, posts, 2)
+	assert.Equal(t, map[string]interface{}{"Title": "Hello"}, posts[0])
+	assert.Equal(t, map[string]interface{}{"Title": "World"}, posts[1])
+}
+
+func TestPickAllNested(t *testing.T) {
+	author := NestedAuthor{
+		Name:    "Jane",
+		Address: &NestedAddress{City: "Paris"},
+		Posts:   []NestedPost{{Title: "Hello", Body: "World"}},
+	}
+
+	m := New().PickAllNested().Transform(author)
+
+	assert.Equal(t, "Jane", m["Name"])
+	assert.Equal(t, map[string]interface{}{"City": "Paris"}, m["Address"])
+	posts := m["Posts"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"Title": "Hello", "Body": "World"}, posts[0])
+}
+
+func TestPickAllNestedPreservesKeyCase(t *testing.T) {
+	author := NestedAuthor{Address: &NestedAddress{City: "Paris"}}
+
+	m := New().UseSnakeCase().PickAllNested().Transform(author)
+
+	assert.Equal(t, map[string]interface{}{"city": "Paris"}, m["address"])
+}
+
+func TestPickWithDetectsCycles(t *testing.T) {
+	node := &NestedNode{Name: "root"}
+	node.Next = node
+	nodeDef := NewDefinition().PickAllNested()
+
+	m := New().PickWith("Address", nodeDef).Transform(struct{ Address *NestedNode }{Address: node})
+
+	result := m["Address"].(map[string]interface{})
+	assert.Equal(t, "root", result["Name"])
+	assert.Nil(t, result["Next"])
+}
@@ -0,0 +1,146 @@
+package structomap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isSlicePath reports whether path contains a "[]" slice marker, e.g.
+// "Comments[].Author.Email". Such paths cannot be represented by a single
+// flat key (the picked value is inherently an array of sub-objects), so
+// Pick/PickFunc/Omit build a nested map mirroring the path instead of
+// falling back to the flattened dotted-path behavior used for plain paths
+// such as "Address.City".
+func isSlicePath(path string) bool {
+	return strings.Contains(path, "[]")
+}
+
+// slicePathRootKey returns the output key a slice path is stored under:
+// the name of its first segment, with the "[]" marker stripped.
+func slicePathRootKey(path string) string {
+	root := strings.SplitN(path, ".", 2)[0]
+	return strings.TrimSuffix(root, "[]")
+}
+
+// resolveSlicePath walks v along path, applying converter to every leaf
+// value it finds and building a map/slice structure mirroring path, e.g.
+// "Comments[].Author.Email" yields []interface{}{map[string]interface{}{
+// "Author": map[string]interface{}{"Email": ...}}, ...}. Every key but the
+// root (which the caller's own key-case handling already covers) is passed
+// through keyCase, so nested keys match the active case just like flat
+// Pick keys do. It returns an error if path traverses an unexported field.
+//
+// Two Pick/PickFunc paths that share the same root segment (e.g.
+// "Comments[].Title" and "Comments[].Author.Email") are not merged: each
+// call overwrites whatever the previous one stored under that root key, so
+// the last Pick call for a given root wins.
+func resolveSlicePath(v reflect.Value, path string, converter ValueConverter, keyCase KeyConverter) (interface{}, error) {
+	return buildPathValue(v, strings.Split(path, "."), converter, keyCase)
+}
+
+func buildPathValue(v reflect.Value, segments []string, converter ValueConverter, keyCase KeyConverter) (interface{}, error) {
+	v = reflect.Indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	isSlice := strings.HasSuffix(segments[0], "[]")
+	name := strings.TrimSuffix(segments[0], "[]")
+	rest := segments[1:]
+
+	field, ok := v.Type().FieldByName(name)
+	if !ok {
+		return nil, fmt.Errorf("structomap: no such field %q in path", name)
+	}
+	if field.PkgPath != "" {
+		return nil, fmt.Errorf("structomap: path traverses unexported field %q", name)
+	}
+	fieldValue := v.FieldByName(name)
+
+	if !isSlice {
+		return pathLeafOrNested(fieldValue, rest, converter, keyCase)
+	}
+
+	sliceValue := reflect.Indirect(fieldValue)
+	if !sliceValue.IsValid() {
+		return []interface{}{}, nil
+	}
+	if sliceValue.Kind() != reflect.Slice && sliceValue.Kind() != reflect.Array {
+		return nil, fmt.Errorf("structomap: path segment %q is not a slice", segments[0])
+	}
+	result := make([]interface{}, sliceValue.Len())
+	for i := 0; i < sliceValue.Len(); i++ {
+		elem, err := pathLeafOrNested(sliceValue.Index(i), rest, converter, keyCase)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = elem
+	}
+	return result, nil
+}
+
+func pathLeafOrNested(v reflect.Value, rest []string, converter ValueConverter, keyCase KeyConverter) (interface{}, error) {
+	if len(rest) == 0 {
+		indirect := reflect.Indirect(v)
+		if !indirect.IsValid() {
+			return converter(nil), nil
+		}
+		return converter(indirect.Interface()), nil
+	}
+	nested, err := buildPathValue(v, rest, converter, keyCase)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.TrimSuffix(rest[0], "[]")
+	if keyCase != nil {
+		key = keyCase(key)
+	}
+	return map[string]interface{}{key: nested}, nil
+}
+
+// pruneSlicePath deletes the leaf named by path from m, descending through
+// nested maps and, at a "[]" segment, every element of the slice found
+// there. It is a no-op wherever the path doesn't match what's in m, e.g.
+// because the field was never Picked in the first place. The root segment
+// is looked up as-is (the top-level map is still pre-conversion at modifier
+// time, same as pruneSlicePath's caller sees it), but every other segment
+// is passed through keyCase first, mirroring how pathLeafOrNested cases the
+// nested keys it builds on the Pick side.
+func pruneSlicePath(m jsonMap, path string, keyCase KeyConverter) {
+	pruneSegments(map[string]interface{}(m), strings.Split(path, "."), keyCase, true)
+}
+
+func pruneSegments(container interface{}, segments []string, keyCase KeyConverter, isRoot bool) {
+	m, ok := container.(map[string]interface{})
+	if !ok {
+		return
+	}
+	name := strings.TrimSuffix(segments[0], "[]")
+	if !isRoot && keyCase != nil {
+		name = keyCase(name)
+	}
+	isSlice := strings.HasSuffix(segments[0], "[]")
+	rest := segments[1:]
+
+	if len(rest) == 0 {
+		delete(m, name)
+		return
+	}
+
+	next, ok := m[name]
+	if !ok {
+		return
+	}
+	if !isSlice {
+		pruneSegments(next, rest, keyCase, false)
+		return
+	}
+	items, ok := next.([]interface{})
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		pruneSegments(item, rest, keyCase, false)
+	}
+}
@@ -0,0 +1,111 @@
+package structomap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PathAuthor struct {
+	Name  string
+	Email string
+}
+
+type PathComment struct {
+	Title  string
+	Author PathAuthor
+}
+
+type PathArticle struct {
+	Comments []PathComment
+	private  string
+}
+
+func TestPickSlicePath(t *testing.T) {
+	article := PathArticle{
+		Comments: []PathComment{
+			{Title: "Hi", Author: PathAuthor{Name: "Jane", Email: "jane@example.com"}},
+			{Title: "Yo", Author: PathAuthor{Name: "Bob", Email: "bob@example.com"}},
+		},
+	}
+
+	m := New().Pick("Comments[].Author.Email").Transform(article)
+
+	comments := m["Comments"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"Author": map[string]interface{}{"Email": "jane@example.com"}}, comments[0])
+	assert.Equal(t, map[string]interface{}{"Author": map[string]interface{}{"Email": "bob@example.com"}}, comments[1])
+}
+
+func TestPickFuncSlicePathAppliesConverterToLeaves(t *testing.T) {
+	article := PathArticle{
+		Comments: []PathComment{
+			{Title: "Hi", Author: PathAuthor{Email: "jane@example.com"}},
+		},
+	}
+
+	upper := func(v interface{}) interface{} {
+		return strings.ToUpper(v.(string))
+	}
+	m := New().PickFunc(upper, "Comments[].Author.Email").Transform(article)
+
+	comments := m["Comments"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"Author": map[string]interface{}{"Email": "JANE@EXAMPLE.COM"}}, comments[0])
+}
+
+func TestOmitSlicePathPrunesLeaf(t *testing.T) {
+	article := PathArticle{
+		Comments: []PathComment{
+			{Title: "Hi", Author: PathAuthor{Name: "Jane", Email: "jane@example.com"}},
+		},
+	}
+
+	m := New().
+		PickAllNested().
+		Omit("Comments[].Author").
+		Transform(article)
+	comments := m["Comments"].([]interface{})
+	entry := comments[0].(map[string]interface{})
+	assert.NotContains(t, entry, "Author")
+	assert.Equal(t, "Hi", entry["Title"])
+}
+
+func TestOmitSlicePathPrunesLeafUnderSnakeCase(t *testing.T) {
+	article := PathArticle{
+		Comments: []PathComment{
+			{Title: "Hi", Author: PathAuthor{Name: "Jane", Email: "jane@example.com"}},
+		},
+	}
+
+	m := New().
+		UseSnakeCase().
+		Pick("Comments[].Author.Email").
+		Omit("Comments[].Author.Email").
+		Transform(article)
+
+	comments := m["comments"].([]interface{})
+	entry := comments[0].(map[string]interface{})
+	author := entry["author"].(map[string]interface{})
+	assert.NotContains(t, author, "email")
+}
+
+func TestPickSlicePathRejectsUnexportedField(t *testing.T) {
+	article := PathArticle{private: "secret"}
+
+	assert.PanicsWithError(t, `structomap: path traverses unexported field "private"`, func() {
+		New().Pick("private[].Name").Transform(article)
+	})
+}
+
+func TestPickSlicePathNestedKeysHonorKeyCase(t *testing.T) {
+	article := PathArticle{
+		Comments: []PathComment{
+			{Title: "Hi", Author: PathAuthor{Name: "Jane", Email: "jane@example.com"}},
+		},
+	}
+
+	m := New().UseSnakeCase().Pick("Comments[].Author.Email").Transform(article)
+
+	comments := m["comments"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"author": map[string]interface{}{"email": "jane@example.com"}}, comments[0])
+}
@@ -0,0 +1,81 @@
+package structomap
+
+// PredicateCtx carries the entity being transformed together with the
+// serializer's active scope (set via WithScope) to a ScopedPredicate.
+type PredicateCtx struct {
+	Entity interface{}
+	Scope  string
+}
+
+// ScopedPredicate is like Predicate but also receives the serializer's
+// active scope, so one serializer can expose "public", "admin" or "owner"
+// projections without wiring the scope into a closure by hand.
+type ScopedPredicate func(ctx PredicateCtx) bool
+
+// Scoped adapts p into a ScopedPredicate that ignores the scope, acting as a
+// shim so existing Predicate values keep working with the *Scoped methods.
+func (p Predicate) Scoped() ScopedPredicate {
+	return func(ctx PredicateCtx) bool { return p(ctx.Entity) }
+}
+
+// WithScope sets the active scope, read by ScopedPredicate-based rules
+func (b *Base) WithScope(scope string) Serializer {
+	b.scope = scope
+	return b
+}
+
+func (b *Base) predicateCtx() PredicateCtx {
+	return PredicateCtx{Entity: b.raw, Scope: b.scope}
+}
+
+// PickIfScoped adds the given fields to the result if the ScopedPredicate
+// returns true for the active scope
+func (b *Base) PickIfScoped(p ScopedPredicate, keys ...string) Serializer {
+	return b.PickFuncIfScoped(p, identity, keys...)
+}
+
+// PickFuncIfScoped adds the given fields to the result after applying the
+// converter if the ScopedPredicate returns true for the active scope
+func (b *Base) PickFuncIfScoped(p ScopedPredicate, converter ValueConverter, keys ...string) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.predicateCtx()) {
+			for _, key := range keys {
+				m[b.outputKey(key)] = converter(b.fieldValue(key))
+			}
+		}
+		return m
+	})
+	return b
+}
+
+// OmitIfScoped omits the given fields from the result if the
+// ScopedPredicate returns true for the active scope
+func (b *Base) OmitIfScoped(p ScopedPredicate, keys ...string) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.predicateCtx()) {
+			for _, key := range keys {
+				delete(m, b.outputKey(key))
+			}
+		}
+		return m
+	})
+	return b
+}
+
+// AddIfScoped adds a custom field to the result if the ScopedPredicate
+// returns true for the active scope
+func (b *Base) AddIfScoped(p ScopedPredicate, key string, value interface{}) Serializer {
+	return b.AddFuncIfScoped(p, key, func(interface{}) interface{} { return value })
+}
+
+// AddFuncIfScoped adds a computed custom field to the result if the
+// ScopedPredicate returns true for the active scope
+func (b *Base) AddFuncIfScoped(p ScopedPredicate, key string, f ValueConverter) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		if p(b.predicateCtx()) {
+			m[key] = f(b.raw)
+		}
+		return m
+	})
+	return b
+}
@@ -0,0 +1,54 @@
+package structomap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func publicScope(ctx PredicateCtx) bool {
+	return ctx.Scope == "public"
+}
+
+func adminScope(ctx PredicateCtx) bool {
+	return ctx.Scope == "admin"
+}
+
+func TestWithScopePickIfScoped(t *testing.T) {
+	serializer := New().
+		Pick("ID").
+		PickIfScoped(adminScope, "Email")
+
+	m := serializer.WithScope("public").Transform(user)
+	assert.NotContains(t, m, "Email")
+
+	m = serializer.WithScope("admin").Transform(user)
+	assert.Contains(t, m, "Email")
+}
+
+func TestWithScopeOmitIfScoped(t *testing.T) {
+	serializer := New().PickAll().OmitIfScoped(publicScope, "Email")
+
+	m := serializer.WithScope("public").Transform(user)
+	assert.NotContains(t, m, "Email")
+
+	m = serializer.WithScope("admin").Transform(user)
+	assert.Contains(t, m, "Email")
+}
+
+func TestWithScopeAddFuncIfScoped(t *testing.T) {
+	serializer := New().AddFuncIfScoped(adminScope, "Secret", func(interface{}) interface{} {
+		return "s3cr3t"
+	})
+
+	m := serializer.WithScope("public").Transform(user)
+	assert.NotContains(t, m, "Secret")
+
+	m = serializer.WithScope("admin").Transform(user)
+	assert.Equal(t, "s3cr3t", m["Secret"])
+}
+
+func TestPredicateScopedShim(t *testing.T) {
+	m := New().PickIfScoped(Predicate(alwaysTrue).Scoped(), "ID").Transform(user)
+	assert.Contains(t, m, "ID")
+}
@@ -1,11 +1,14 @@
-// Package serializer contains
-package serializer
+// Package structomap contains
+package structomap
 
 import (
+	"context"
 	"fmt"
 	"github.com/fatih/structs"
 	"github.com/huandu/xstrings"
+	"io"
 	"reflect"
+	"strings"
 )
 
 type KeyCase int
@@ -15,6 +18,7 @@ const (
 	CamelCase          = iota
 	PascalCase         = iota
 	SnakeCase          = iota
+	LowerCase          = iota
 )
 
 var defaultCase KeyCase = NotSet
@@ -59,7 +63,9 @@ type Serializer interface {
 	// Add all the exported fields to the result
 	PickAll() Serializer
 
-	// Add the given fields to the result
+	// Add the given fields to the result. A "[]" slice-path key (e.g.
+	// "Comments[].Author.Email") panics during Transform if it traverses a
+	// field that doesn't exist or isn't exported.
 	Pick(keys ...string) Serializer
 
 	// Add the given fields to the result if the Predicate returns true
@@ -88,6 +94,114 @@ type Serializer interface {
 
 	// Add a computed custom field to the result if the Predicate returns true
 	AddFuncIf(predicate Predicate, key string, converter ValueConverter) Serializer
+
+	// Transform the field named key with sub and nest the result under key,
+	// respecting the outer key case
+	Nest(key string, sub Serializer) Serializer
+
+	// Transform the field named field with sub and flatten the result into
+	// the parent map, prepending prefix to every key. A missing field is a
+	// no-op, same as Nest on a missing field would be.
+	NestWithPrefix(field, prefix string, sub Serializer) Serializer
+
+	// Configure Pick/Omit/AddFunc rules from the struct's `structomap` tags
+	FromTags() Serializer
+
+	// Transform the entity and encode the result to w using the encoder
+	// registered for format
+	TransformTo(entity interface{}, w io.Writer, format string) error
+
+	// Transform the entities and stream the result to w using the encoder
+	// registered for format, one element at a time
+	TransformArrayTo(entities interface{}, w io.Writer, format string) error
+
+	// Control whether a dotted field path like "Address.City" produces a
+	// flattened key (address_city, the default) or just its last segment
+	// (city)
+	FlattenNestedKeys(enabled bool) Serializer
+
+	// Set the active scope, read by ScopedPredicate-based rules
+	WithScope(scope string) Serializer
+
+	// Add the given fields to the result if the ScopedPredicate returns true
+	PickIfScoped(predicate ScopedPredicate, keys ...string) Serializer
+
+	// Add the given fields to the result after applying the converter if
+	// the ScopedPredicate returns true
+	PickFuncIfScoped(predicate ScopedPredicate, converter ValueConverter, keys ...string) Serializer
+
+	// Omit the given fields from the result if the ScopedPredicate returns true
+	OmitIfScoped(predicate ScopedPredicate, keys ...string) Serializer
+
+	// Add a custom field to the result if the ScopedPredicate returns true
+	AddIfScoped(predicate ScopedPredicate, key string, value interface{}) Serializer
+
+	// Add a computed custom field to the result if the ScopedPredicate returns true
+	AddFuncIfScoped(predicate ScopedPredicate, key string, converter ValueConverter) Serializer
+
+	// Read field keys (and omitempty) from the given struct tag (e.g. "json")
+	// instead of using the Go field name, for PickAll, Pick, PickFunc and Omit
+	WithTag(tag string) Serializer
+
+	// Shorthand for the ConvertKeys/UseXCase family, picking the key case
+	// from a KeyCase value
+	WithKeyCase(keyCase KeyCase) Serializer
+
+	// Override the output key a single field resolves to, regardless of
+	// WithTag or the active key case
+	Rename(field, newName string) Serializer
+
+	// Add the given struct, slice-of-struct or map-of-struct field to the
+	// result, transformed recursively with sub
+	PickWith(field string, sub Serializer) Serializer
+
+	// Add all the exported fields to the result, transforming nested struct,
+	// slice-of-struct and map-of-struct fields recursively instead of
+	// relying on structs.Map's default expansion
+	PickAllNested() Serializer
+
+	// Transform entity and encode the result with the encoder registered
+	// for format, returning the encoded bytes
+	Marshal(entity interface{}, format string) ([]byte, error)
+
+	// Transform entity and encode the result as JSON
+	ToJSON(entity interface{}) ([]byte, error)
+
+	// Transform entity and encode the result as MessagePack
+	ToMsgPack(entity interface{}) ([]byte, error)
+
+	// Transform entity and encode the result as BSON
+	ToBSON(entity interface{}) ([]byte, error)
+
+	// Set the context.Context threaded into every *Ctx rule
+	WithContext(ctx context.Context) Serializer
+
+	// Add the given fields to the result if the CtxPredicate returns true
+	// for the active context
+	PickIfCtx(predicate CtxPredicate, keys ...string) Serializer
+
+	// Add the given fields to the result after applying the CtxValueConverter
+	PickFuncCtx(converter CtxValueConverter, keys ...string) Serializer
+
+	// Add the given fields to the result after applying the
+	// CtxValueConverter if the CtxPredicate returns true for the active
+	// context
+	PickFuncIfCtx(predicate CtxPredicate, converter CtxValueConverter, keys ...string) Serializer
+
+	// Omit the given fields from the result if the CtxPredicate returns
+	// true for the active context
+	OmitIfCtx(predicate CtxPredicate, keys ...string) Serializer
+
+	// Add a custom field to the result if the CtxPredicate returns true for
+	// the active context
+	AddIfCtx(predicate CtxPredicate, key string, value interface{}) Serializer
+
+	// Add a computed custom field to the result
+	AddFuncCtx(key string, converter CtxValueConverter) Serializer
+
+	// Add a computed custom field to the result if the CtxPredicate returns
+	// true for the active context
+	AddFuncIfCtx(predicate CtxPredicate, key string, converter CtxValueConverter) Serializer
 }
 
 func alwaysTrue(u interface{}) bool {
@@ -104,15 +218,22 @@ func identity(u interface{}) interface{} {
 
 // A basic implementation of Serializer
 type Base struct {
-	raw          interface{}
-	modifiers    []mapModifier
-	reflected    reflect.Value
-	keyConverter KeyConverter
+	raw               interface{}
+	modifiers         []mapModifier
+	reflected         reflect.Value
+	keyConverter      KeyConverter
+	rawKeys           map[string]bool
+	flattenNestedKeys bool
+	scope             string
+	tagName           string
+	renames           map[string]string
+	nestSeen          map[uintptr]bool
+	ctx               context.Context
 }
 
 // Creates a new serializer
 func New() *Base {
-	b := &Base{}
+	b := &Base{flattenNestedKeys: true}
 	b.addDefaultKeyConverter()
 	return b
 }
@@ -124,17 +245,25 @@ func (b *Base) Transform(entity interface{}) map[string]interface{} {
 }
 
 func (b *Base) TransformArray(entities interface{}) ([]map[string]interface{}, error) {
-	s := reflect.ValueOf(entities)
-	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
-		return nil, fmt.Errorf("TransformArray() given a non-slice type")
+	s, err := sliceValue(entities)
+	if err != nil {
+		return nil, err
 	}
-	var result []map[string]interface{}
+	result := make([]map[string]interface{}, 0, s.Len())
 	for i := 0; i < s.Len(); i++ {
 		result = append(result, b.Transform(s.Index(i).Interface()))
 	}
 	return result, nil
 }
 
+func sliceValue(entities interface{}) (reflect.Value, error) {
+	s := reflect.ValueOf(entities)
+	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("TransformArray() given a non-slice type")
+	}
+	return s, nil
+}
+
 func (b *Base) MustTransformArray(entities interface{}) []map[string]interface{} {
 	res, err := b.TransformArray(entities)
 	if err != nil {
@@ -159,11 +288,22 @@ func (b *Base) addDefaultKeyConverter() {
 func (b *Base) transformedResult(result jsonMap) jsonMap {
 	newResult := make(map[string]interface{})
 	for key, value := range result {
-		newResult[b.keyConverter(key)] = value
+		if b.rawKeys[key] {
+			newResult[key] = value
+		} else {
+			newResult[b.keyConverter(key)] = value
+		}
 	}
 	return newResult
 }
 
+func (b *Base) markRawKey(key string) {
+	if b.rawKeys == nil {
+		b.rawKeys = make(map[string]bool)
+	}
+	b.rawKeys[key] = true
+}
+
 func (b *Base) result() map[string]interface{} {
 	result := make(map[string]interface{})
 	for _, modifier := range b.modifiers {
@@ -199,11 +339,37 @@ func (b *Base) UseSnakeCase() Serializer {
 
 func (b *Base) PickAll() Serializer {
 	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
-		return structs.Map(b.raw)
+		if b.tagName == "" && len(b.renames) == 0 {
+			return structs.Map(b.raw)
+		}
+		return b.pickAllTagged(m)
 	})
 	return b
 }
 
+// pickAllTagged is the PickAll() path taken once WithTag or Rename is used,
+// walking the fields by hand instead of delegating to structs.Map so that
+// tag-derived keys, omitempty and renames can be honored.
+func (b *Base) pickAllTagged(m jsonMap) jsonMap {
+	t := b.reflected.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		info, tagged := b.tagInfo(field)
+		if tagged && info.omit {
+			continue
+		}
+		value := b.reflected.Field(i)
+		if tagged && info.omitempty && value.IsZero() {
+			continue
+		}
+		m[b.fieldOutputKey(field.Name, info, tagged)] = value.Interface()
+	}
+	return m
+}
+
 func (b *Base) Pick(keys ...string) Serializer {
 	return b.PickFunc(identity, keys...)
 }
@@ -220,7 +386,25 @@ func (b *Base) PickFuncIf(p Predicate, converter ValueConverter, keys ...string)
 	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
 		if p(b.raw) {
 			for _, key := range keys {
-				m[key] = converter(b.reflected.FieldByName(key).Interface())
+				if isSlicePath(key) {
+					// Pick/PickFunc/PickFuncIf have no error return, so a
+					// path segment naming a missing or unexported field
+					// panics here, during Transform, with the same
+					// fmt.Errorf message resolveSlicePath built; it's
+					// surfaced this way rather than silently dropped,
+					// consistent with MustTransformArray's documented panic.
+					value, err := resolveSlicePath(b.reflected, key, converter, b.keyConverter)
+					if err != nil {
+						panic(err)
+					}
+					m[slicePathRootKey(key)] = value
+					continue
+				}
+				value := b.fieldValue(key)
+				if b.outputOmitEmpty(key) && isZeroValue(value) {
+					continue
+				}
+				m[b.outputKey(key)] = converter(value)
 			}
 		}
 		return m
@@ -236,7 +420,11 @@ func (b *Base) OmitIf(p Predicate, keys ...string) Serializer {
 	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
 		if p(b.raw) {
 			for _, key := range keys {
-				delete(m, key)
+				if isSlicePath(key) {
+					pruneSlicePath(m, key, b.keyConverter)
+					continue
+				}
+				delete(m, b.outputKey(key))
 			}
 		}
 		return m
@@ -244,6 +432,75 @@ func (b *Base) OmitIf(p Predicate, keys ...string) Serializer {
 	return b
 }
 
+func (b *Base) FlattenNestedKeys(enabled bool) Serializer {
+	b.flattenNestedKeys = enabled
+	return b
+}
+
+// fieldValue resolves a field name or dotted field path such as
+// "Address.City" against the current entity.
+func (b *Base) fieldValue(key string) interface{} {
+	if !strings.Contains(key, ".") {
+		return b.reflected.FieldByName(key).Interface()
+	}
+	return resolveFieldPath(b.reflected, key)
+}
+
+// outputKey turns a field name or dotted field path into the key it should
+// be stored under, before the active key case is applied.
+func (b *Base) outputKey(key string) string {
+	if strings.Contains(key, ".") {
+		segments := strings.Split(key, ".")
+		if !b.flattenNestedKeys {
+			return segments[len(segments)-1]
+		}
+		return strings.Join(segments, "_")
+	}
+	if newName, ok := b.renames[key]; ok {
+		b.markRawKey(newName)
+		return newName
+	}
+	if field, ok := b.reflected.Type().FieldByName(key); ok {
+		if info, tagged := b.tagInfo(field); tagged && !info.omit && info.key != "" {
+			b.markRawKey(info.key)
+			return info.key
+		}
+	}
+	return key
+}
+
+// outputOmitEmpty reports whether key should be dropped from the result when
+// its value is the zero value, per its WithTag directive.
+func (b *Base) outputOmitEmpty(key string) bool {
+	if strings.Contains(key, ".") {
+		return false
+	}
+	field, ok := b.reflected.Type().FieldByName(key)
+	if !ok {
+		return false
+	}
+	info, tagged := b.tagInfo(field)
+	return tagged && info.omitempty
+}
+
+// resolveFieldPath walks v through pointer and struct kinds following the
+// dotted path, stopping and returning nil if a nil pointer is encountered
+// along the way instead of panicking.
+func resolveFieldPath(v reflect.Value, path string) interface{} {
+	current := v
+	for _, segment := range strings.Split(path, ".") {
+		current = reflect.Indirect(current)
+		if !current.IsValid() {
+			return nil
+		}
+		current = current.FieldByName(segment)
+		if !current.IsValid() {
+			return nil
+		}
+	}
+	return current.Interface()
+}
+
 func (b *Base) Add(key string, value interface{}) Serializer {
 	return b.AddIf(alwaysTrue, key, value)
 }
@@ -265,3 +522,31 @@ func (b *Base) AddFuncIf(p Predicate, key string, f ValueConverter) Serializer {
 	})
 	return b
 }
+
+func (b *Base) Nest(key string, sub Serializer) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		value := b.reflected.FieldByName(key)
+		if !value.IsValid() {
+			return m
+		}
+		m[key] = sub.Transform(value.Interface())
+		return m
+	})
+	return b
+}
+
+func (b *Base) NestWithPrefix(field, prefix string, sub Serializer) Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		value := b.reflected.FieldByName(field)
+		if !value.IsValid() {
+			return m
+		}
+		for key, fieldValue := range sub.Transform(value.Interface()) {
+			flatKey := prefix + key
+			b.markRawKey(flatKey)
+			m[flatKey] = fieldValue
+		}
+		return m
+	})
+	return b
+}
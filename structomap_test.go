@@ -244,6 +244,7 @@ func TestUsePascalCase(t *testing.T) {
 }
 
 func TestDefaultCase(t *testing.T) {
+	defer SetDefaultCase(NotSet)
 	SetDefaultCase(SnakeCase)
 	m := New().PickAll().Transform(user)
 	assert.Contains(t, m, "first_name")
@@ -287,6 +288,98 @@ func TestTransformEmptyArray(t *testing.T) {
 	assert.Len(t, result, 0)
 }
 
+type Address struct {
+	City string
+}
+
+type Player struct {
+	Name  string
+	Votes int
+}
+
+type Match struct {
+	Player1 Player
+	Player2 Player
+}
+
+func TestNest(t *testing.T) {
+	type Account struct {
+		Address Address
+	}
+	account := Account{Address: Address{City: "Paris"}}
+	addressSerializer := New().UseSnakeCase().Pick("City")
+	m := New().UseSnakeCase().Nest("Address", addressSerializer).Transform(account)
+	assert.Contains(t, m, "address")
+	assert.Equal(t, map[string]interface{}{"city": "Paris"}, m["address"])
+}
+
+func TestNestWithPrefix(t *testing.T) {
+	match := Match{
+		Player1: Player{Name: "Alice", Votes: 3},
+		Player2: Player{Name: "Bob", Votes: 1},
+	}
+	playerSerializer := New().UseSnakeCase().Pick("Name", "Votes")
+	m := New().UseSnakeCase().
+		NestWithPrefix("Player1", "player1_", playerSerializer).
+		NestWithPrefix("Player2", "player2_", playerSerializer).
+		Transform(match)
+	assert.Equal(t, "Alice", m["player1_name"])
+	assert.Equal(t, 3, m["player1_votes"])
+	assert.Equal(t, "Bob", m["player2_name"])
+	assert.Equal(t, 1, m["player2_votes"])
+}
+
+func TestNestWithPrefixUnderCamelCaseOuter(t *testing.T) {
+	match := Match{
+		Player1: Player{Name: "Alice", Votes: 3},
+	}
+	playerSerializer := New().UseSnakeCase().Pick("Name", "Votes")
+	m := New().UseCamelCase().
+		NestWithPrefix("Player1", "player1_", playerSerializer).
+		Transform(match)
+	assert.Equal(t, "Alice", m["player1_name"])
+	assert.Equal(t, 3, m["player1_votes"])
+}
+
+func TestNestWithPrefixMissingField(t *testing.T) {
+	match := Match{Player1: Player{Name: "Alice", Votes: 3}}
+	playerSerializer := New().UseSnakeCase().Pick("Name", "Votes")
+	m := New().UseSnakeCase().
+		NestWithPrefix("NoSuchField", "missing_", playerSerializer).
+		Transform(match)
+	assert.NotContains(t, m, "missing_name")
+}
+
+func TestPickNestedPath(t *testing.T) {
+	type Account struct {
+		Owner   User
+		Manager *User
+	}
+	account := Account{Owner: User{FirstName: "Foo"}}
+	m := New().UseSnakeCase().Pick("Owner.FirstName", "Manager.FirstName").Transform(account)
+	assert.Equal(t, "Foo", m["owner_first_name"])
+	assert.Nil(t, m["manager_first_name"])
+}
+
+func TestPickNestedPathFlattenNestedKeysDisabled(t *testing.T) {
+	type Account struct {
+		Owner User
+	}
+	account := Account{Owner: User{FirstName: "Foo"}}
+	m := New().UseSnakeCase().FlattenNestedKeys(false).Pick("Owner.FirstName").Transform(account)
+	assert.Equal(t, "Foo", m["first_name"])
+	assert.NotContains(t, m, "owner_first_name")
+}
+
+func TestOmitNestedPath(t *testing.T) {
+	type Account struct {
+		Owner User
+	}
+	account := Account{Owner: User{FirstName: "Foo"}}
+	m := New().UseSnakeCase().Pick("Owner.FirstName").Omit("Owner.FirstName").Transform(account)
+	assert.NotContains(t, m, "owner_first_name")
+}
+
 func TestCustomSerializer(t *testing.T) {
 	m := NewCustomSerializer().WithPrivateinfo().WithBasicInfo().Transform(user)
 	for _, field := range []string{"Id", "FirstName", "LastName", "Email"} {
@@ -0,0 +1,99 @@
+package structomap
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/structs"
+)
+
+const structTag = "structomap"
+
+type tagOptions struct {
+	name      string
+	omit      bool
+	omitempty bool
+	pick      bool
+	prefix    string
+	format    string
+}
+
+func parseTagOptions(tag string) tagOptions {
+	if tag == "-" {
+		return tagOptions{omit: true}
+	}
+	var opts tagOptions
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case part == "omitempty":
+			opts.omitempty = true
+		case part == "pick":
+			opts.pick = true
+		case strings.HasPrefix(part, "name="):
+			opts.name = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "prefix="):
+			opts.prefix = strings.TrimPrefix(part, "prefix=")
+		case strings.HasPrefix(part, "format="):
+			opts.format = strings.TrimPrefix(part, "format=")
+		}
+	}
+	return opts
+}
+
+// FromTags walks the reflected struct's exported fields and configures
+// Pick/Omit/AddFunc rules from `structomap:"..."` tags: name=foo renames the
+// key (bypassing the active key case), - always omits the field, omitempty
+// omits it when its value is the zero value, prefix=foo_ flattens an embedded
+// struct field the way NestWithPrefix does, and format=rfc3339 formats a
+// time.Time field. Embedded fields are skipped unless tagged with pick or
+// prefix=, since they otherwise need an explicit flattening rule. Combine
+// with other builder methods, e.g. New().FromTags().Add(...), to layer
+// programmatic rules on top.
+func (b *Base) FromTags() Serializer {
+	b.modifiers = append(b.modifiers, func(m jsonMap) jsonMap {
+		t := b.reflected.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			opts := parseTagOptions(field.Tag.Get(structTag))
+			if opts.omit {
+				continue
+			}
+			if field.Anonymous && !opts.pick && opts.prefix == "" {
+				continue
+			}
+			value := b.reflected.Field(i)
+			if opts.omitempty && value.IsZero() {
+				continue
+			}
+			if opts.prefix != "" {
+				for key, fieldValue := range structs.Map(value.Interface()) {
+					flatKey := opts.prefix + key
+					b.markRawKey(flatKey)
+					m[flatKey] = fieldValue
+				}
+				continue
+			}
+			key := field.Name
+			if opts.name != "" {
+				key = opts.name
+				b.markRawKey(key)
+			}
+			m[key] = formatTaggedValue(value, opts.format)
+		}
+		return m
+	})
+	return b
+}
+
+func formatTaggedValue(value reflect.Value, format string) interface{} {
+	if format == "rfc3339" {
+		if t, ok := value.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return value.Interface()
+}
@@ -0,0 +1,68 @@
+package structomap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TaggedUser struct {
+	ID        int
+	Email     string    `structomap:"name=contact_email"`
+	Password  string    `structomap:"-"`
+	Nickname  string    `structomap:"omitempty"`
+	CreatedAt time.Time `structomap:"format=rfc3339"`
+	Address   TaggedAddress
+}
+
+type TaggedAddress struct {
+	City string
+}
+
+func TestFromTags(t *testing.T) {
+	u := TaggedUser{
+		ID:        1,
+		Email:     "x@example.com",
+		Password:  "secret",
+		CreatedAt: createdAt,
+	}
+	m := New().FromTags().Transform(u)
+	assert.Equal(t, 1, m["ID"])
+	assert.Equal(t, "x@example.com", m["contact_email"])
+	assert.NotContains(t, m, "Password")
+	assert.NotContains(t, m, "Nickname")
+	assert.Equal(t, createdAt.Format(time.RFC3339), m["CreatedAt"])
+	assert.Equal(t, TaggedAddress{}, m["Address"])
+}
+
+func TestFromTagsWithKeyCase(t *testing.T) {
+	u := TaggedUser{ID: 1, Email: "x@example.com", CreatedAt: createdAt}
+	m := New().UseSnakeCase().FromTags().Transform(u)
+	assert.Contains(t, m, "id")
+	assert.Equal(t, "x@example.com", m["contact_email"])
+}
+
+type EmbeddedUser struct {
+	ID      int
+	Address TaggedAddress `structomap:"prefix=address_"`
+}
+
+func TestFromTagsPrefix(t *testing.T) {
+	u := EmbeddedUser{ID: 1, Address: TaggedAddress{City: "Paris"}}
+	m := New().FromTags().Transform(u)
+	assert.Equal(t, "Paris", m["address_City"])
+	assert.NotContains(t, m, "Address")
+}
+
+func TestFromTagsPrefixUnderCamelCaseOuter(t *testing.T) {
+	u := EmbeddedUser{ID: 1, Address: TaggedAddress{City: "Paris"}}
+	m := New().UseCamelCase().FromTags().Transform(u)
+	assert.Equal(t, "Paris", m["address_City"])
+}
+
+func TestFromTagsCombinesWithBuilderMethods(t *testing.T) {
+	u := TaggedUser{ID: 1, Email: "x@example.com", CreatedAt: createdAt}
+	m := New().FromTags().Add("Extra", "value").Transform(u)
+	assert.Equal(t, "value", m["Extra"])
+}